@@ -3,15 +3,17 @@ package errorx
 const defaultCode = 500
 
 type CodeError struct {
-	Code    int     `json:"code"`
-	Msg     string  `json:"msg"`
-	Details []error `json:"details"`
+	Code    int          `json:"code"`
+	Msg     string       `json:"msg"`
+	Details []error      `json:"details"`
+	Fields  []FieldError `json:"fields,omitempty"`
 }
 
 type CodeErrorResponse struct {
-	Code    int      `json:"code"`
-	Msg     string   `json:"msg"`
-	Details []string `json:"details"`
+	Code    int          `json:"code"`
+	Msg     string       `json:"msg"`
+	Details []string     `json:"details"`
+	Fields  []FieldError `json:"fields,omitempty"`
 }
 
 func NewCodeError(code int, msg string, details ...error) *CodeError {
@@ -44,5 +46,6 @@ func (e *CodeError) Data() *CodeErrorResponse {
 		Code:    e.Code,
 		Msg:     e.Msg,
 		Details: details,
+		Fields:  e.Fields,
 	}
 }
@@ -0,0 +1,81 @@
+package errorx
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	FieldRequired = "REQUIRED"
+	FieldInvalid  = "INVALID"
+)
+
+// httpx.Parse 的错误只有文本，按其中能取到字段名的几种格式转换
+var parseErrorPatterns = []struct {
+	code string
+	re   *regexp.Regexp
+}{
+	{FieldRequired, regexp.MustCompile(`^field (\S+) is not set$`)},
+	{FieldInvalid, regexp.MustCompile(`^error: type mismatch for field (\S+)$`)},
+	{FieldInvalid, regexp.MustCompile(`^error: value ".*" for field "([^"]+)" is not defined in options`)},
+}
+
+type (
+	// FieldError 单个字段的校验错误，Key 供前端做多语言映射
+	FieldError struct {
+		Code    string `json:"code"`
+		Field   string `json:"field"`
+		Message string `json:"message"`
+		Key     string `json:"key"`
+	}
+
+	// FieldErrors 收集一次校验中的全部字段错误，handler 和 logic 共用
+	FieldErrors []FieldError
+)
+
+func NewFieldError(code, field, msg string) FieldError {
+	return FieldError{
+		Code:    code,
+		Field:   field,
+		Message: msg,
+		Key:     "validation." + strings.ToLower(code),
+	}
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// NewValidationError 每次新建 INVALID_ARGUMENT，避免修改共享的 InvalidArgument
+func NewValidationError(fields ...FieldError) *CodeError {
+	return &CodeError{
+		Code:   http.StatusBadRequest,
+		Msg:    "INVALID_ARGUMENT",
+		Fields: fields,
+	}
+}
+
+func (fe *FieldErrors) Add(code, field, msg string) {
+	*fe = append(*fe, NewFieldError(code, field, msg))
+}
+
+// Err 没有字段错误时返回 nil
+func (fe FieldErrors) Err() error {
+	if len(fe) == 0 {
+		return nil
+	}
+	return NewValidationError(fe...)
+}
+
+// FromParseError 将 httpx.Parse 的错误转换为带字段名的 INVALID_ARGUMENT，取不到字段名时原样返回
+func FromParseError(err error) error {
+	msg := err.Error()
+	for _, p := range parseErrorPatterns {
+		if m := p.re.FindStringSubmatch(msg); m != nil {
+			return NewValidationError(NewFieldError(p.code, m[1], msg))
+		}
+	}
+
+	return err
+}
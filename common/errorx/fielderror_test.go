@@ -0,0 +1,107 @@
+package errorx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+func TestFieldErrors(t *testing.T) {
+	var fields FieldErrors
+	assert.Nil(t, fields.Err())
+
+	fields.Add(FieldRequired, "name", "name is required")
+	err, ok := fields.Err().(*CodeError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, err.Code)
+	assert.Equal(t, "INVALID_ARGUMENT", err.Msg)
+	assert.Equal(t, []FieldError{{
+		Code:    FieldRequired,
+		Field:   "name",
+		Message: "name is required",
+		Key:     "validation.required",
+	}}, err.Data().Fields)
+	assert.Empty(t, InvalidArgument.Fields)
+}
+
+func TestCodeErrorDataOmitsEmptyFields(t *testing.T) {
+	body, err := json.Marshal(NewCodeError(http.StatusNotFound, "NOT_FOUND").Data())
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "fields")
+}
+
+func TestFromParseError(t *testing.T) {
+	type jsonReq struct {
+		Name string `json:"name"`
+		Kind string `json:"kind,options=a|b"`
+	}
+	type formReq struct {
+		Kind string `form:"kind,options=a|b"`
+	}
+	jsonRequest := func(body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		return r
+	}
+
+	tests := []struct {
+		name  string
+		r     *http.Request
+		v     interface{}
+		code  string
+		field string
+	}{
+		{
+			name:  "missing json field",
+			r:     jsonRequest(`{"kind": "a"}`),
+			v:     &jsonReq{},
+			code:  FieldRequired,
+			field: "name",
+		},
+		{
+			name:  "json type mismatch",
+			r:     jsonRequest(`{"name": 1, "kind": "a"}`),
+			v:     &jsonReq{},
+			code:  FieldInvalid,
+			field: "name",
+		},
+		{
+			name:  "form value not in options",
+			r:     httptest.NewRequest(http.MethodGet, "/?kind=c", nil),
+			v:     &formReq{},
+			code:  FieldInvalid,
+			field: "kind",
+		},
+		{
+			// json 的 options 错误信息中没有字段名，原样返回
+			name: "json value not in options",
+			r:    jsonRequest(`{"name": "n", "kind": "c"}`),
+			v:    &jsonReq{},
+		},
+	}
+
+	for _, test := range tests {
+		parseErr := httpx.Parse(test.r, test.v)
+		assert.NotNil(t, parseErr, test.name)
+
+		if test.field == "" {
+			assert.Equal(t, parseErr, FromParseError(parseErr), test.name)
+			continue
+		}
+
+		err, ok := FromParseError(parseErr).(*CodeError)
+		assert.True(t, ok, test.name)
+		assert.Equal(t, []FieldError{NewFieldError(test.code, test.field, parseErr.Error())}, err.Fields, test.name)
+	}
+}
+
+func TestCodeErrorFieldsTagsMatch(t *testing.T) {
+	body, err := json.Marshal(NewCodeError(http.StatusNotFound, "NOT_FOUND"))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(body), "fields")
+}
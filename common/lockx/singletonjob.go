@@ -0,0 +1,95 @@
+package lockx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/lang"
+	"github.com/tal-tech/go-zero/core/logx"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+	"github.com/tal-tech/go-zero/core/threading"
+)
+
+const defaultExpire = 60 // 秒，需大于各实例 cron 触发时间的最大偏差
+
+// SingletonJob 多实例部署时，保证同一个周期任务的每次调度只在一个实例上执行
+type SingletonJob struct {
+	store  *redis.Redis
+	key    string
+	expire int
+}
+
+func NewSingletonJob(store *redis.Redis, key string) *SingletonJob {
+	return &SingletonJob{
+		store:  store,
+		key:    key,
+		expire: defaultExpire,
+	}
+}
+
+// WithExpire 非正数会让续期的 ticker panic，忽略并保留原值
+func (j *SingletonJob) WithExpire(seconds int) *SingletonJob {
+	if seconds > 0 {
+		j.expire = seconds
+	} else {
+		logx.Errorf("invalid lock expire %d for %s, keep %d", seconds, j.key, j.expire)
+	}
+	return j
+}
+
+// Run 同时抢到任务级的运行锁和 tick 对应周期的锁时执行 fn 并返回 true，否则不执行，返回 false。
+// tick 传入 cron 的计划触发时间（而非实际触发时间），各实例据此得到同一把周期锁。
+// 运行锁不含 tick，fn 结束即释放，保证上一周期未跑完时其他实例不会开始下一周期；
+// 周期锁执行完毕后不释放而是等待过期，避免触发较晚的实例把同一周期再执行一遍。
+// 两把锁在 fn 执行期间都会定时续期。
+func (j *SingletonJob) Run(tick time.Time, fn func() error) (bool, error) {
+	running := j.newLock(j.key + ":running")
+	ok, err := running.Acquire()
+	if err != nil || !ok {
+		return false, err
+	}
+	defer func() {
+		if _, err := running.Release(); err != nil {
+			logx.Errorf("release lock %s failed: %v", j.key, err)
+		}
+	}()
+
+	period := j.newLock(fmt.Sprintf("%s:%d", j.key, tick.Unix()))
+	ok, err = period.Acquire()
+	if err != nil || !ok {
+		return false, err
+	}
+
+	done := make(chan lang.PlaceholderType)
+	defer close(done)
+	threading.GoSafe(func() {
+		j.renew(done, running, period)
+	})
+
+	return true, fn()
+}
+
+func (j *SingletonJob) newLock(key string) *redis.RedisLock {
+	lock := redis.NewRedisLock(j.store, key)
+	lock.SetExpire(j.expire)
+	return lock
+}
+
+// renew 持有锁的实例再次 Acquire 即重置过期时间
+func (j *SingletonJob) renew(done <-chan lang.PlaceholderType, locks ...*redis.RedisLock) {
+	ticker := time.NewTicker(time.Duration(j.expire) * time.Second / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			for _, lock := range locks {
+				if ok, err := lock.Acquire(); err != nil || !ok {
+					logx.Errorf("renew lock %s failed: %v", j.key, err)
+				}
+			}
+		}
+	}
+}
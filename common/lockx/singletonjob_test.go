@@ -0,0 +1,134 @@
+package lockx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/stores/redis"
+)
+
+func newStore(t *testing.T) (*miniredis.Miniredis, *redis.Redis) {
+	mr, err := miniredis.Run()
+	assert.Nil(t, err)
+	t.Cleanup(mr.Close)
+	return mr, redis.NewRedis(mr.Addr(), redis.NodeType)
+}
+
+func TestSingletonJobRunsOncePerTick(t *testing.T) {
+	_, store := newStore(t)
+	tick := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+	var count int32
+	job := func() error {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		delay := time.Duration(i) * 50 * time.Millisecond
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 模拟两个实例的 cron 触发时间存在偏差，后者触发时前者已执行完毕
+			time.Sleep(delay)
+			_, err := NewSingletonJob(store, "job").Run(tick, job)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+}
+
+func TestSingletonJobRunsEachTick(t *testing.T) {
+	_, store := newStore(t)
+	tick := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+	job := NewSingletonJob(store, "job")
+
+	ok, err := job.Run(tick, func() error { return nil })
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = job.Run(tick.Add(time.Minute), func() error { return nil })
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestSingletonJobRenewsWhileRunning(t *testing.T) {
+	mr, store := newStore(t)
+	tick := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	result := make(chan bool)
+
+	go func() {
+		ok, _ := NewSingletonJob(store, "job").WithExpire(1).Run(tick, func() error {
+			close(started)
+			<-finish
+			return nil
+		})
+		result <- ok
+	}()
+	<-started
+
+	// 累计快进远超过期时间，续期未生效时锁早已过期
+	for i := 0; i < 5; i++ {
+		time.Sleep(400 * time.Millisecond)
+		mr.FastForward(time.Second)
+	}
+
+	ok, err := NewSingletonJob(store, "job").WithExpire(1).Run(tick, func() error {
+		t.Error("job ran while another instance was still running it")
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	close(finish)
+	assert.True(t, <-result)
+}
+
+func TestSingletonJobSkipsNextTickWhileRunning(t *testing.T) {
+	_, store := newStore(t)
+	tick := time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC)
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	result := make(chan bool)
+
+	go func() {
+		ok, _ := NewSingletonJob(store, "job").Run(tick, func() error {
+			close(started)
+			<-finish
+			return nil
+		})
+		result <- ok
+	}()
+	<-started
+
+	// 上一周期仍在其他实例上执行，下一周期不能并行开始
+	ok, err := NewSingletonJob(store, "job").Run(tick.Add(time.Minute), func() error {
+		t.Error("next tick ran while the previous one was still running")
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	close(finish)
+	assert.True(t, <-result)
+
+	ok, err = NewSingletonJob(store, "job").Run(tick.Add(2*time.Minute), func() error { return nil })
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestSingletonJobIgnoresInvalidExpire(t *testing.T) {
+	_, store := newStore(t)
+	assert.Equal(t, defaultExpire, NewSingletonJob(store, "job").WithExpire(0).expire)
+	assert.Equal(t, defaultExpire, NewSingletonJob(store, "job").WithExpire(-1).expire)
+	assert.Equal(t, 5, NewSingletonJob(store, "job").WithExpire(5).expire)
+}
@@ -1,9 +1,6 @@
 package middlewarex
 
-import (
-	"github.com/tal-tech/go-zero/rest/httpx"
-	"net/http"
-)
+import "net/http"
 
 type CrosMiddleware struct {
 }
@@ -18,8 +15,8 @@ func (m *CrosMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
-		if r.Method == "OPTIONS" {
-			httpx.Error(w, http.ErrBodyNotAllowed)
+		// 预检请求只返回 204，不能再写 body，否则状态码会被先写入的错误响应覆盖
+		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
@@ -27,3 +24,11 @@ func (m *CrosMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
 		next(w, r)
 	}
 }
+
+// NewCrosNotAllowedHandler 用于 rest.WithNotAllowedHandler。路由没有注册 OPTIONS，
+// 预检请求在进入路由中间件之前就会被路由器按 405 处理，因此在这里统一应答预检
+func NewCrosNotAllowedHandler() http.Handler {
+	return NewCrosMiddleware().Handle(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+}
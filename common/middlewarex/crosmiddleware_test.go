@@ -0,0 +1,23 @@
+package middlewarex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrosMiddlewarePreflight(t *testing.T) {
+	handler := NewCrosMiddleware().Handle(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("preflight request should not reach the next handler")
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/status", nil)
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Empty(t, resp.Body.String())
+	assert.Equal(t, "*", resp.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,131 @@
+package policyx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"project_temp/common/errorx"
+)
+
+const defaultTimeout = time.Second
+
+var denyAll = LocalEvaluator(func(ctx context.Context, in Input) (bool, error) {
+	return false, nil
+})
+
+type (
+	// Input 策略评估的请求上下文
+	Input struct {
+		Tenant   string `json:"tenant"`
+		User     string `json:"user"`
+		Action   string `json:"action"`
+		Resource string `json:"resource"`
+	}
+
+	Evaluator interface {
+		Allow(ctx context.Context, in Input) (bool, error)
+	}
+
+	// LocalEvaluator 本地策略，外部策略引擎不可用时兜底
+	LocalEvaluator func(ctx context.Context, in Input) (bool, error)
+
+	// OpaEvaluator 通过 OPA Data API 评估策略，url 形如 http://opa:8181/v1/data/project/allow
+	OpaEvaluator struct {
+		url    string
+		client *http.Client
+	}
+
+	// FallbackEvaluator 优先使用外部策略引擎，调用失败时回退到本地策略
+	FallbackEvaluator struct {
+		remote Evaluator
+		local  Evaluator
+	}
+)
+
+func (f LocalEvaluator) Allow(ctx context.Context, in Input) (bool, error) {
+	return f(ctx, in)
+}
+
+func NewOpaEvaluator(url string) *OpaEvaluator {
+	return &OpaEvaluator{
+		url:    url,
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+func (e *OpaEvaluator) WithTimeout(timeout time.Duration) *OpaEvaluator {
+	e.client.Timeout = timeout
+	return e
+}
+
+func (e *OpaEvaluator) Allow(ctx context.Context, in Input) (bool, error) {
+	body, err := json.Marshal(map[string]Input{"input": in})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("opa responded with status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Result bool `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, err
+	}
+
+	return out.Result, nil
+}
+
+// NewFallbackEvaluator local 为 nil 时，外部策略引擎不可用即拒绝
+func NewFallbackEvaluator(remote, local Evaluator) *FallbackEvaluator {
+	if local == nil {
+		local = denyAll
+	}
+
+	return &FallbackEvaluator{
+		remote: remote,
+		local:  local,
+	}
+}
+
+func (e *FallbackEvaluator) Allow(ctx context.Context, in Input) (bool, error) {
+	ok, err := e.remote.Allow(ctx, in)
+	if err == nil {
+		return ok, nil
+	}
+
+	logx.WithContext(ctx).Errorf("policy engine unavailable, fallback to local policy: %v", err)
+	return e.local.Allow(ctx, in)
+}
+
+// Check 在敏感操作前调用，未通过时返回 PERMISSION_DENIED，无法评估时返回 UNAVAILABLE
+func Check(ctx context.Context, e Evaluator, in Input) error {
+	ok, err := e.Allow(ctx, in)
+	if err != nil {
+		return errorx.NewCodeError(http.StatusServiceUnavailable, "UNAVAILABLE", err)
+	}
+	if !ok {
+		return errorx.PERMISSION_DENIED
+	}
+
+	return nil
+}
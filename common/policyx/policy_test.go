@@ -0,0 +1,131 @@
+package policyx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"project_temp/common/errorx"
+)
+
+var input = Input{
+	Tenant:   "t1",
+	User:     "u1",
+	Action:   "delete",
+	Resource: "order",
+}
+
+func newOpaServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	svr := httptest.NewServer(handler)
+	t.Cleanup(svr.Close)
+	return svr
+}
+
+func opaResult(t *testing.T, result bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input Input `json:"input"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, input, body.Input)
+		assert.Nil(t, json.NewEncoder(w).Encode(map[string]bool{"result": result}))
+	}
+}
+
+func localResult(called *bool, result bool) LocalEvaluator {
+	return func(ctx context.Context, in Input) (bool, error) {
+		*called = true
+		return result, nil
+	}
+}
+
+func TestFallbackEvaluator(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		local       bool
+		allow       bool
+		localCalled bool
+	}{
+		{
+			name:    "opa allow",
+			handler: opaResult(t, true),
+			allow:   true,
+		},
+		{
+			name:    "opa deny",
+			handler: opaResult(t, false),
+			local:   true,
+			allow:   false,
+		},
+		{
+			name: "opa error falls back to local",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			local:       true,
+			allow:       true,
+			localCalled: true,
+		},
+		{
+			name: "opa timeout falls back to local",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				opaResult(t, true)(w, r)
+			},
+			local:       false,
+			allow:       false,
+			localCalled: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			svr := newOpaServer(t, test.handler)
+			var called bool
+			e := NewFallbackEvaluator(NewOpaEvaluator(svr.URL).WithTimeout(50*time.Millisecond),
+				localResult(&called, test.local))
+
+			allow, err := e.Allow(context.Background(), input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.allow, allow)
+			assert.Equal(t, test.localCalled, called)
+		})
+	}
+}
+
+func TestFallbackEvaluatorDeniesWithoutLocal(t *testing.T) {
+	svr := newOpaServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	allow, err := NewFallbackEvaluator(NewOpaEvaluator(svr.URL), nil).Allow(context.Background(), input)
+	assert.Nil(t, err)
+	assert.False(t, allow)
+}
+
+func TestCheck(t *testing.T) {
+	allow := LocalEvaluator(func(ctx context.Context, in Input) (bool, error) {
+		return true, nil
+	})
+	assert.Nil(t, Check(context.Background(), allow, input))
+
+	assert.Equal(t, errorx.PERMISSION_DENIED, Check(context.Background(), denyAll, input))
+
+	cause := errors.New("policy engine down")
+	broken := LocalEvaluator(func(ctx context.Context, in Input) (bool, error) {
+		return false, cause
+	})
+	err := Check(context.Background(), broken, input)
+	codeErr, ok := err.(*errorx.CodeError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, codeErr.Code)
+	assert.Equal(t, []error{cause}, codeErr.Details)
+	assert.Len(t, errorx.UNAVAILABLE.Details, 1)
+}
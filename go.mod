@@ -3,7 +3,9 @@ module project_temp
 go 1.16
 
 require (
+	github.com/alicebob/miniredis/v2 v2.14.1
 	github.com/golang/protobuf v1.4.3
+	github.com/stretchr/testify v1.5.1
 	github.com/tal-tech/go-zero v1.1.5
 	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110 // indirect
 	golang.org/x/sys v0.0.0-20210309074719-68d13333faf2 // indirect
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"project_temp/common/errorx"
 	"project_temp/service/order/cmd/api/internal/logic"
 	"project_temp/service/order/cmd/api/internal/svc"
 	"project_temp/service/order/cmd/api/internal/types"
@@ -14,7 +15,7 @@ func getOrderHandler(ctx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req types.OrderReq
 		if err := httpx.Parse(r, &req); err != nil {
-			httpx.Error(w, err)
+			httpx.Error(w, errorx.FromParseError(err))
 			return
 		}
 
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"project_temp/service/order/cmd/api/internal/logic"
+	"project_temp/service/order/cmd/api/internal/svc"
+
+	"github.com/tal-tech/go-zero/rest/httpx"
+)
+
+func getStatusHandler(ctx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewGetStatusLogic(r.Context(), ctx)
+		resp, err := l.GetStatus()
+		if err != nil {
+			httpx.Error(w, err)
+		} else {
+			// 可用性探测只看状态码，非 ok 时返回 503，且不缓存，以免恢复后仍显示故障
+			if resp.Status != logic.StatusOk {
+				w.Header().Set("Cache-Control", "no-store")
+				httpx.WriteJson(w, http.StatusServiceUnavailable, resp)
+			} else {
+				// 无需鉴权，允许状态页/CDN 短时间缓存
+				w.Header().Set("Cache-Control", "public, max-age=30")
+				httpx.OkJson(w, resp)
+			}
+		}
+	}
+}
@@ -10,6 +10,19 @@ import (
 )
 
 func RegisterHandlers(engine *rest.Server, serverCtx *svc.ServiceContext) {
+	engine.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Cros},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/status",
+					Handler: getStatusHandler(serverCtx),
+				},
+			}...,
+		),
+	)
+
 	engine.AddRoutes(
 		rest.WithMiddlewares(
 			[]rest.Middleware{serverCtx.Cros, serverCtx.Auth},
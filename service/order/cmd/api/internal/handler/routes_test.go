@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tal-tech/go-zero/core/conf"
+	"github.com/tal-tech/go-zero/rest"
+	"project_temp/common/middlewarex"
+	"project_temp/service/order/cmd/api/internal/svc"
+)
+
+func startServer(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	assert.Nil(t, ln.Close())
+
+	var c rest.RestConf
+	assert.Nil(t, conf.LoadConfigFromJsonBytes([]byte(fmt.Sprintf(
+		`{"Name": "order-test", "Host": "127.0.0.1", "Port": %d, "Log": {"Mode": "console"}}`, port)), &c))
+	server := rest.MustNewServer(c, rest.WithNotAllowedHandler(middlewarex.NewCrosNotAllowedHandler()))
+	RegisterHandlers(server, &svc.ServiceContext{
+		Auth: func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				t.Error("preflight request should not reach the auth middleware")
+			}
+		},
+		Cros: middlewarex.NewCrosMiddleware().Handle,
+	})
+	go server.Start()
+
+	addr := fmt.Sprintf("http://127.0.0.1:%d", port)
+	for i := 0; i < 50; i++ {
+		if conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	return addr
+}
+
+func TestRoutesPreflight(t *testing.T) {
+	addr := startServer(t)
+
+	for _, path := range []string{"/status", "/api/order/get/1"} {
+		req, err := http.NewRequest(http.MethodOptions, addr+path, nil)
+		assert.Nil(t, err)
+		req.Header.Set("Origin", "https://status.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, path)
+		assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"), path)
+		assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Authorization", path)
+	}
+
+	resp, err := http.Post(addr+"/status", "application/json", nil)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
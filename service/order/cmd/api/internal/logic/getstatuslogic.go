@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"context"
+
+	"project_temp/service/order/cmd/api/internal/svc"
+	"project_temp/service/order/cmd/api/internal/types"
+
+	"github.com/tal-tech/go-zero/core/logx"
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+type GetStatusLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) GetStatusLogic {
+	return GetStatusLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetStatus 只返回粗粒度的组件状态，不暴露地址等内部信息
+func (l *GetStatusLogic) GetStatus() (*types.StatusReply, error) {
+	components := []types.ComponentStatus{
+		{Name: "order.api", Status: StatusOk},
+		{Name: "user.rpc", Status: rpcStatus(l.svcCtx.UserRpcCli.Conn().GetState())},
+	}
+
+	return &types.StatusReply{
+		Status:     overallStatus(components),
+		Components: components,
+	}, nil
+}
+
+// overallStatus 所有订单接口都依赖 user.rpc，整体状态取各组件中最差的一个
+func overallStatus(components []types.ComponentStatus) string {
+	status := StatusOk
+	for _, c := range components {
+		if c.Status == StatusDown {
+			return StatusDown
+		}
+		if c.Status != StatusOk {
+			status = StatusDegraded
+		}
+	}
+
+	return status
+}
+
+func rpcStatus(state connectivity.State) string {
+	switch state {
+	case connectivity.Ready, connectivity.Idle:
+		return StatusOk
+	case connectivity.Connecting:
+		return StatusDegraded
+	default:
+		return StatusDown
+	}
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/connectivity"
+	"project_temp/service/order/cmd/api/internal/types"
+)
+
+func TestRpcStatus(t *testing.T) {
+	tests := []struct {
+		state  connectivity.State
+		status string
+	}{
+		{connectivity.Idle, StatusOk},
+		{connectivity.Ready, StatusOk},
+		{connectivity.Connecting, StatusDegraded},
+		{connectivity.TransientFailure, StatusDown},
+		{connectivity.Shutdown, StatusDown},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.status, rpcStatus(test.state), test.state.String())
+	}
+}
+
+func TestOverallStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		status   string
+	}{
+		{"all ok", []string{StatusOk, StatusOk}, StatusOk},
+		{"one degraded", []string{StatusOk, StatusDegraded}, StatusDegraded},
+		{"one down", []string{StatusOk, StatusDown}, StatusDown},
+		{"down wins over degraded", []string{StatusDegraded, StatusDown}, StatusDown},
+		{"down before degraded", []string{StatusDown, StatusDegraded}, StatusDown},
+	}
+
+	for _, test := range tests {
+		components := make([]types.ComponentStatus, 0, len(test.statuses))
+		for _, s := range test.statuses {
+			components = append(components, types.ComponentStatus{Name: "c", Status: s})
+		}
+		assert.Equal(t, test.status, overallStatus(components), test.name)
+	}
+}
@@ -11,18 +11,21 @@ import (
 type ServiceContext struct {
 	Config config.Config
 
-	AuthCheck rest.Middleware
-	Cros      rest.Middleware
+	Auth rest.Middleware
+	Cros rest.Middleware
 
-	UserRpc userclient.User
+	UserRpcCli zrpc.Client // 供健康检查读取连接状态
+	UserRpc    userclient.User
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
-	userRpc := userclient.NewUser(zrpc.MustNewClient(c.UserRpc))
+	userRpcCli := zrpc.MustNewClient(c.UserRpc)
+	userRpc := userclient.NewUser(userRpcCli)
 	return &ServiceContext{
-		Config:    c,
-		AuthCheck: middlewarex.NewAuthMiddleware(userRpc).Handle,
-		Cros:      middlewarex.NewCrosMiddleware().Handle,
-		UserRpc:   userRpc,
+		Config:     c,
+		Auth:       middlewarex.NewAuthMiddleware(userRpc).Handle,
+		Cros:       middlewarex.NewCrosMiddleware().Handle,
+		UserRpcCli: userRpcCli,
+		UserRpc:    userRpc,
 	}
 }
@@ -9,3 +9,13 @@ type OrderReply struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
 }
+
+type ComponentStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type StatusReply struct {
+	Status     string            `json:"status"`
+	Components []ComponentStatus `json:"components"`
+}
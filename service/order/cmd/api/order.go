@@ -6,6 +6,7 @@ import (
 	"github.com/tal-tech/go-zero/rest/httpx"
 	"net/http"
 	"project_temp/common/errorx"
+	"project_temp/common/middlewarex"
 
 	"project_temp/service/order/cmd/api/internal/config"
 	"project_temp/service/order/cmd/api/internal/handler"
@@ -24,7 +25,7 @@ func main() {
 	conf.MustLoad(*configFile, &c)
 
 	ctx := svc.NewServiceContext(c)
-	server := rest.MustNewServer(c.RestConf)
+	server := rest.MustNewServer(c.RestConf, rest.WithNotAllowedHandler(middlewarex.NewCrosNotAllowedHandler()))
 	defer server.Stop()
 
 	handler.RegisterHandlers(server, ctx)